@@ -0,0 +1,219 @@
+package rest
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// API describes a REST service: a set of Routes together with the
+// settings createOpenAPI needs to turn them into an OpenAPI document.
+type API struct {
+	// Name is used as the title of the generated OpenAPI document.
+	Name string
+	// Routes holds every registered route, in registration order.
+	Routes []*Route
+	// KnownTypes lets callers supply a hand-written schema for a Go type
+	// instead of letting getSchema derive one via reflection.
+	KnownTypes map[reflect.Type]*openapi3.Schema
+	// StripPkgPaths lists package path prefixes to omit when naming
+	// generated schema components, e.g. so "github.com/foo/bar.Widget"
+	// is registered simply as "Widget".
+	StripPkgPaths []string
+
+	spec   *openapi3.T
+	codecs map[string]Codec
+	oneOfs map[reflect.Type]oneOfRegistration
+}
+
+// NewAPI creates an API named name with the default codecs registered:
+// JSON, YAML, XML, and form-urlencoded. Use RegisterCodec to add more or
+// to replace one of the defaults.
+func NewAPI(name string) *API {
+	api := &API{
+		Name:       name,
+		KnownTypes: make(map[reflect.Type]*openapi3.Schema),
+	}
+	api.RegisterCodec(MimeJSON, jsonCodec{})
+	api.RegisterCodec(MimeYAML, yamlCodec{})
+	api.RegisterCodec(MimeXML, xmlCodec{})
+	api.RegisterCodec(MimeForm, formCodec{})
+	return api
+}
+
+// Route binds a path to the request/response models for each HTTP method
+// registered on it.
+type Route struct {
+	Path           string
+	MethodToModels map[string]Models
+	// DisableValidation exempts this route from ValidatorMiddleware.
+	DisableValidation bool
+
+	// Tags groups this route's operations in the generated spec.
+	Tags []string
+	// OperationID is the generated operation's operationId. It defaults
+	// to empty, which lets kin-openapi/Swagger UI fall back to the
+	// method and path.
+	OperationID string
+	// Summary and Description populate the corresponding operation
+	// fields; Summary is expected to be a single short sentence, while
+	// Description may be longer and support CommonMark.
+	Summary     string
+	Description string
+	// Deprecated marks every operation on this route as deprecated.
+	Deprecated bool
+	// Params describes the path, query, and header parameters this
+	// route accepts, in addition to any chi URL params implied by Path.
+	Params []Param
+}
+
+// Param describes a single path, query, or header parameter accepted by
+// a Route.
+type Param struct {
+	// Name is the parameter name, e.g. the chi URL param name for an
+	// "In: ParamInPath" parameter.
+	Name string
+	In   ParamLocation
+	// Type determines the generated schema for this parameter's value.
+	Type     reflect.Type
+	Required bool
+}
+
+// ParamLocation is where in the request a Param is found.
+type ParamLocation string
+
+const (
+	ParamInPath   ParamLocation = "path"
+	ParamInQuery  ParamLocation = "query"
+	ParamInHeader ParamLocation = "header"
+)
+
+// WithValidationDisabled exempts r from ValidatorMiddleware and returns r
+// for chaining.
+func (r *Route) WithValidationDisabled() *Route {
+	r.DisableValidation = true
+	return r
+}
+
+// WithTags appends tags to r's operations and returns r for chaining.
+func (r *Route) WithTags(tags ...string) *Route {
+	r.Tags = append(r.Tags, tags...)
+	return r
+}
+
+// WithOperationID sets r's operationId and returns r for chaining.
+func (r *Route) WithOperationID(id string) *Route {
+	r.OperationID = id
+	return r
+}
+
+// WithSummary sets r's summary and returns r for chaining.
+func (r *Route) WithSummary(summary string) *Route {
+	r.Summary = summary
+	return r
+}
+
+// WithDescription sets r's description and returns r for chaining.
+func (r *Route) WithDescription(description string) *Route {
+	r.Description = description
+	return r
+}
+
+// WithDeprecated marks r as deprecated and returns r for chaining.
+func (r *Route) WithDeprecated() *Route {
+	r.Deprecated = true
+	return r
+}
+
+// WithParam appends a parameter to r and returns r for chaining.
+func (r *Route) WithParam(p Param) *Route {
+	r.Params = append(r.Params, p)
+	return r
+}
+
+// WithParamsFromStruct derives Params from the exported fields of the
+// struct type of v, one per field. The parameter's location comes from
+// whichever of the "path", "query", or "header" struct tags is present
+// on the field (the tag's value is the parameter name); fields with
+// none of those tags are skipped. A `binding:"required"` tag marks the
+// parameter required.
+func (r *Route) WithParamsFromStruct(v interface{}) *Route {
+	r.Params = append(r.Params, paramsFromStruct(reflect.TypeOf(v))...)
+	return r
+}
+
+// chiParamPattern matches a chi URL parameter segment, e.g. "{id}" or
+// "{id:[0-9]+}", capturing just the parameter name.
+var chiParamPattern = regexp.MustCompile(`\{(\w+)(?::[^}]*)?\}`)
+
+// effectiveParams returns r.Params merged with every chi URL parameter
+// implied by r.Path that isn't already declared there: a route path like
+// "/widgets/{id}" gets an implicit required string ParamInPath named
+// "id" unless the caller already added a Path param of that name (via
+// WithParam or WithParamsFromStruct) to give it a more specific Type.
+func (r *Route) effectiveParams() []Param {
+	declared := make(map[string]bool, len(r.Params))
+	for _, p := range r.Params {
+		if p.In == ParamInPath {
+			declared[p.Name] = true
+		}
+	}
+	params := r.Params
+	for _, match := range chiParamPattern.FindAllStringSubmatch(r.Path, -1) {
+		name := match[1]
+		if declared[name] {
+			continue
+		}
+		params = append(params, Param{
+			Name:     name,
+			In:       ParamInPath,
+			Type:     reflect.TypeOf(""),
+			Required: true,
+		})
+	}
+	return params
+}
+
+func paramsFromStruct(t reflect.Type) []Param {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var params []Param
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		for _, loc := range []ParamLocation{ParamInPath, ParamInQuery, ParamInHeader} {
+			name := f.Tag.Get(string(loc))
+			if name == "" {
+				continue
+			}
+			params = append(params, Param{
+				Name:     name,
+				In:       loc,
+				Type:     f.Type,
+				Required: isRequiredTag(f.Tag),
+			})
+			break
+		}
+	}
+	return params
+}
+
+// Models describes the request and response schemas for a single
+// operation (one HTTP method on one Route).
+type Models struct {
+	Request   ModelInfo
+	Responses map[int]ModelInfo
+}
+
+// ModelInfo pairs a Go type with the metadata getSchema needs to describe
+// it in the generated spec.
+type ModelInfo struct {
+	Type reflect.Type
+}
@@ -0,0 +1,249 @@
+package rest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Well-known media types with codecs registered by NewAPI.
+const (
+	MimeJSON = "application/json"
+	MimeYAML = "application/yaml"
+	MimeXML  = "application/xml"
+	MimeForm = "application/x-www-form-urlencoded"
+)
+
+// Codec encodes and decodes values for a single media type.
+type Codec interface {
+	Encode(w io.Writer, v interface{}) error
+	Decode(r io.Reader, v interface{}) error
+}
+
+// RegisterCodec registers codec to handle mimeType, both when generating
+// the OpenAPI spec's Content maps and when DecodeRequest/EncodeResponse
+// negotiate a representation. Registering a codec under a mime type that
+// already has one replaces it.
+func (api *API) RegisterCodec(mimeType string, codec Codec) {
+	if api.codecs == nil {
+		api.codecs = make(map[string]Codec)
+	}
+	api.codecs[mimeType] = codec
+}
+
+// contentTypes lists the mime types createOpenAPI should describe for
+// request bodies, in a stable order. APIs built without NewAPI have no
+// registered codecs; default to application/json so existing callers
+// keep their current behavior.
+func (api *API) contentTypes() []string {
+	if len(api.codecs) == 0 {
+		return []string{MimeJSON}
+	}
+	types := make([]string, 0, len(api.codecs))
+	for mimeType := range api.codecs {
+		types = append(types, mimeType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// encodeCapable is implemented by codecs that can only Decode, never
+// Encode, so they should be omitted from the response side of a
+// generated spec's Content map (advertising a representation
+// EncodeResponse can never actually deliver).
+type encodeCapable interface {
+	CanEncode() bool
+}
+
+// responseContentTypes lists the mime types createOpenAPI should
+// describe for response bodies: the same as contentTypes, but excluding
+// any codec that implements encodeCapable and reports CanEncode() ==
+// false, like formCodec, whose Encode always returns an error.
+func (api *API) responseContentTypes() []string {
+	all := api.contentTypes()
+	types := make([]string, 0, len(all))
+	for _, mimeType := range all {
+		if !canEncode(api.codecs[mimeType]) {
+			continue
+		}
+		types = append(types, mimeType)
+	}
+	return types
+}
+
+// canEncode reports whether codec can be used to encode a response,
+// i.e. it doesn't implement encodeCapable and report CanEncode() ==
+// false the way formCodec does.
+func canEncode(codec Codec) bool {
+	capable, ok := codec.(encodeCapable)
+	return !ok || capable.CanEncode()
+}
+
+// DecodeRequest decodes r's body into v using the codec registered for
+// its Content-Type header, defaulting to application/json if the header
+// is absent.
+func (api *API) DecodeRequest(r *http.Request, v interface{}) error {
+	mimeType := mimeOnly(r.Header.Get("Content-Type"))
+	if mimeType == "" {
+		mimeType = MimeJSON
+	}
+	codec, ok := api.codecs[mimeType]
+	if !ok {
+		return fmt.Errorf("rest: no codec registered for Content-Type %q", mimeType)
+	}
+	return codec.Decode(r.Body, v)
+}
+
+// EncodeResponse picks a codec by negotiating r's Accept header against
+// api's registered codecs, writes the matching Content-Type header, and
+// encodes v with it. It defaults to application/json when Accept is
+// absent, empty, or "*/*".
+func (api *API) EncodeResponse(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	mimeType, codec := api.negotiateAccept(r.Header.Get("Accept"))
+	if codec == nil {
+		return fmt.Errorf("rest: no codec registered for Accept %q", r.Header.Get("Accept"))
+	}
+	w.Header().Set("Content-Type", mimeType)
+	return codec.Encode(w, v)
+}
+
+// negotiateAccept walks accept's media ranges in the order given (q
+// weighting is not considered) and returns the first one with a
+// registered, encode-capable codec, falling back to application/json
+// and then to the first encode-capable codec in sorted mime-type order.
+// Encode-incapable codecs like formCodec are never returned, the same
+// way responseContentTypes excludes them from the generated spec.
+func (api *API) negotiateAccept(accept string) (string, Codec) {
+	for _, candidate := range parseAccept(accept) {
+		if candidate == "" || candidate == "*/*" {
+			break
+		}
+		if codec, ok := api.codecs[candidate]; ok && canEncode(codec) {
+			return candidate, codec
+		}
+	}
+	if codec, ok := api.codecs[MimeJSON]; ok && canEncode(codec) {
+		return MimeJSON, codec
+	}
+	for _, mimeType := range api.responseContentTypes() {
+		if codec, ok := api.codecs[mimeType]; ok {
+			return mimeType, codec
+		}
+	}
+	return "", nil
+}
+
+func parseAccept(accept string) []string {
+	var mimeTypes []string
+	for _, part := range strings.Split(accept, ",") {
+		mimeTypes = append(mimeTypes, mimeOnly(part))
+	}
+	return mimeTypes
+}
+
+func mimeOnly(contentType string) string {
+	return strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, v interface{}) error { return json.NewEncoder(w).Encode(v) }
+func (jsonCodec) Decode(r io.Reader, v interface{}) error { return json.NewDecoder(r).Decode(v) }
+
+type yamlCodec struct{}
+
+func (yamlCodec) Encode(w io.Writer, v interface{}) error { return yaml.NewEncoder(w).Encode(v) }
+func (yamlCodec) Decode(r io.Reader, v interface{}) error { return yaml.NewDecoder(r).Decode(v) }
+
+type xmlCodec struct{}
+
+func (xmlCodec) Encode(w io.Writer, v interface{}) error { return xml.NewEncoder(w).Encode(v) }
+func (xmlCodec) Decode(r io.Reader, v interface{}) error { return xml.NewDecoder(r).Decode(v) }
+
+// formCodec decodes application/x-www-form-urlencoded bodies into a
+// struct's exported fields, matched by "json" tag name the same way
+// getSchema names properties. Encoding is not supported, so its
+// CanEncode reports false to keep it out of generated response Content
+// maps.
+type formCodec struct{}
+
+func (formCodec) CanEncode() bool { return false }
+
+func (formCodec) Encode(w io.Writer, v interface{}) error {
+	return fmt.Errorf("rest: encoding %s is not supported", MimeForm)
+}
+
+func (formCodec) Decode(r io.Reader, v interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("rest: failed to read form body: %w", err)
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return fmt.Errorf("rest: failed to parse form body: %w", err)
+	}
+	return decodeFormValues(values, v)
+}
+
+func decodeFormValues(values url.Values, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("rest: form codec requires a pointer to a struct, got %T", v)
+	}
+	t := rv.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		raw := values.Get(name)
+		if raw == "" {
+			continue
+		}
+		if err := setFormValue(rv.Elem().Field(i), raw); err != nil {
+			return fmt.Errorf("rest: field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setFormValue(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %v", field.Kind())
+	}
+	return nil
+}
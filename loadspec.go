@@ -0,0 +1,240 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// LoadSpec loads hand-written OpenAPI fragments from paths (security
+// schemes, reusable parameters, examples, callbacks, webhooks, and the
+// like) and merges them into the spec createOpenAPI generates from
+// api.Routes. This lets a team keep its authoritative security/auth
+// definitions in YAML while still getting schemas auto-generated from Go
+// types. Fragments are loaded with external $ref resolution enabled, so
+// they may themselves reference other files. The merged, validated spec
+// is cached the same way createOpenAPI's result is.
+func (api *API) LoadSpec(paths ...string) (*openapi3.T, error) {
+	spec, err := api.createOpenAPI()
+	if err != nil {
+		return nil, fmt.Errorf("rest: failed to generate spec: %w", err)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	for _, path := range paths {
+		fragment, err := loader.LoadFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("rest: failed to load spec fragment %q: %w", path, err)
+		}
+		if err := mergeSpec(spec, fragment); err != nil {
+			return nil, fmt.Errorf("rest: failed to merge spec fragment %q: %w", path, err)
+		}
+	}
+
+	if err := spec.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("rest: merged spec failed validation: %w", err)
+	}
+	api.spec = spec
+	return spec, nil
+}
+
+// mergeSpec merges src's paths and components into dst, in place.
+// Components that collide by name with something already in dst are
+// kept only if they marshal identically; otherwise src's copy is
+// renamed (with every "#/components/<section>/<name>" reference to it
+// rewritten to match) before being added, so neither definition is
+// silently dropped.
+func mergeSpec(dst, src *openapi3.T) error {
+	if src.Components != nil {
+		rename := make(map[string]string)
+		mergeComponentSection(dst, "schemas", src.Components.Schemas, rename)
+		mergeComponentSection(dst, "parameters", src.Components.Parameters, rename)
+		mergeComponentSection(dst, "headers", src.Components.Headers, rename)
+		mergeComponentSection(dst, "requestBodies", src.Components.RequestBodies, rename)
+		mergeComponentSection(dst, "responses", src.Components.Responses, rename)
+		mergeComponentSection(dst, "securitySchemes", src.Components.SecuritySchemes, rename)
+		mergeComponentSection(dst, "examples", src.Components.Examples, rename)
+		mergeComponentSection(dst, "links", src.Components.Links, rename)
+		mergeComponentSection(dst, "callbacks", src.Components.Callbacks, rename)
+
+		if len(rename) > 0 {
+			renamed, err := rewriteRefs(src, rename)
+			if err != nil {
+				return fmt.Errorf("deduplicating component names: %w", err)
+			}
+			src = renamed
+		}
+
+		addComponents(dst, src)
+	}
+
+	for path, item := range src.Paths.Map() {
+		if dst.Paths.Value(path) != nil {
+			return fmt.Errorf("path %q is defined by both the generated spec and a loaded fragment", path)
+		}
+		dst.Paths.Set(path, item)
+	}
+	return nil
+}
+
+// mergeComponentSection renames, in place, every key of src that collides
+// with a different entry already recorded under that name in
+// dst[section], and records each old ref -> new ref substitution in
+// rename so rewriteRefs can fix up every "$ref" pointing at the old
+// name. It doesn't mutate dst; addComponents does that once every
+// section has been deduplicated this way.
+func mergeComponentSection[M ~map[string]V, V any](dst *openapi3.T, section string, src M, rename map[string]string) {
+	if len(src) == 0 {
+		return
+	}
+	existing := existingComponentNames(dst, section)
+
+	// Collect renames before applying them: src is being ranged over,
+	// and inserting the new key mid-range is unspecified behavior.
+	type renameOp struct{ oldName, newName string }
+	var ops []renameOp
+	for name, value := range src {
+		if !existing[name] {
+			continue
+		}
+		if componentsEqual(dst, section, name, value) {
+			continue
+		}
+		newName := name
+		for n := 2; existing[newName]; n++ {
+			newName = fmt.Sprintf("%s%d", name, n)
+		}
+		existing[newName] = true
+		ops = append(ops, renameOp{name, newName})
+	}
+
+	for _, op := range ops {
+		src[op.newName] = src[op.oldName]
+		delete(src, op.oldName)
+		rename[fmt.Sprintf("#/components/%s/%s", section, op.oldName)] = fmt.Sprintf("#/components/%s/%s", section, op.newName)
+	}
+}
+
+func existingComponentNames(dst *openapi3.T, section string) map[string]bool {
+	names := make(map[string]bool)
+	var keys []string
+	switch section {
+	case "schemas":
+		keys = mapKeys(dst.Components.Schemas)
+	case "parameters":
+		keys = mapKeys(dst.Components.Parameters)
+	case "headers":
+		keys = mapKeys(dst.Components.Headers)
+	case "requestBodies":
+		keys = mapKeys(dst.Components.RequestBodies)
+	case "responses":
+		keys = mapKeys(dst.Components.Responses)
+	case "securitySchemes":
+		keys = mapKeys(dst.Components.SecuritySchemes)
+	case "examples":
+		keys = mapKeys(dst.Components.Examples)
+	case "links":
+		keys = mapKeys(dst.Components.Links)
+	case "callbacks":
+		keys = mapKeys(dst.Components.Callbacks)
+	}
+	for _, k := range keys {
+		names[k] = true
+	}
+	return names
+}
+
+func mapKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// componentsEqual reports whether dst already has an identical entry
+// named name in section, in which case src's copy can just be dropped
+// instead of renamed.
+func componentsEqual(dst *openapi3.T, section, name string, value interface{}) bool {
+	var existing interface{}
+	switch section {
+	case "schemas":
+		existing = dst.Components.Schemas[name]
+	case "parameters":
+		existing = dst.Components.Parameters[name]
+	case "headers":
+		existing = dst.Components.Headers[name]
+	case "requestBodies":
+		existing = dst.Components.RequestBodies[name]
+	case "responses":
+		existing = dst.Components.Responses[name]
+	case "securitySchemes":
+		existing = dst.Components.SecuritySchemes[name]
+	case "examples":
+		existing = dst.Components.Examples[name]
+	case "links":
+		existing = dst.Components.Links[name]
+	case "callbacks":
+		existing = dst.Components.Callbacks[name]
+	}
+	existingJSON, err1 := json.Marshal(existing)
+	valueJSON, err2 := json.Marshal(value)
+	return err1 == nil && err2 == nil && bytes.Equal(existingJSON, valueJSON)
+}
+
+// addComponents copies every entry of src.Components into dst,
+// overwriting nothing: callers must have already deduplicated names via
+// mergeComponentSection/rewriteRefs.
+func addComponents(dst, src *openapi3.T) {
+	for name, v := range src.Components.Schemas {
+		dst.Components.Schemas[name] = v
+	}
+	for name, v := range src.Components.Parameters {
+		dst.Components.Parameters[name] = v
+	}
+	for name, v := range src.Components.Headers {
+		dst.Components.Headers[name] = v
+	}
+	for name, v := range src.Components.RequestBodies {
+		dst.Components.RequestBodies[name] = v
+	}
+	for name, v := range src.Components.Responses {
+		dst.Components.Responses[name] = v
+	}
+	for name, v := range src.Components.SecuritySchemes {
+		dst.Components.SecuritySchemes[name] = v
+	}
+	for name, v := range src.Components.Examples {
+		dst.Components.Examples[name] = v
+	}
+	for name, v := range src.Components.Links {
+		dst.Components.Links[name] = v
+	}
+	for name, v := range src.Components.Callbacks {
+		dst.Components.Callbacks[name] = v
+	}
+}
+
+// rewriteRefs returns a copy of spec with every "$ref" string replaced
+// according to rename. It works by round-tripping through JSON: the
+// kin-openapi types don't expose a walker over every Ref field, but they
+// all marshal "$ref" the same way, so a textual substitution pass over
+// the serialized form reaches every one of them, nested or not.
+func rewriteRefs(spec *openapi3.T, rename map[string]string) (*openapi3.T, error) {
+	data, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshaling spec to rewrite refs: %w", err)
+	}
+	for oldRef, newRef := range rename {
+		data = bytes.ReplaceAll(data, []byte(`"`+oldRef+`"`), []byte(`"`+newRef+`"`))
+	}
+	rewritten, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("reloading spec after rewriting refs: %w", err)
+	}
+	return rewritten, nil
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// TestMergeSpecRenamesCollidingComponents guards against the bug fixed
+// in e78e5ae, where a colliding component's map key was never actually
+// renamed: src's copy stayed registered under the old name, so
+// addComponents clobbered dst's original entry while every rewritten
+// $ref pointed at a name that didn't exist.
+func TestMergeSpecRenamesCollidingComponents(t *testing.T) {
+	dst := newSpec("dst")
+	dst.Components.Schemas["Widget"] = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+
+	src := newSpec("src")
+	widget := openapi3.NewObjectSchema()
+	widget.Properties = openapi3.Schemas{"name": openapi3.NewSchemaRef("", openapi3.NewStringSchema())}
+	src.Components.Schemas["Widget"] = openapi3.NewSchemaRef("", widget)
+
+	gadget := openapi3.NewObjectSchema()
+	gadget.Properties = openapi3.Schemas{"widget": openapi3.NewSchemaRef("#/components/schemas/Widget", nil)}
+	src.Components.Schemas["Gadget"] = openapi3.NewSchemaRef("", gadget)
+
+	if err := mergeSpec(dst, src); err != nil {
+		t.Fatalf("mergeSpec: %v", err)
+	}
+
+	dstWidget, ok := dst.Components.Schemas["Widget"]
+	if !ok || dstWidget.Value == nil || dstWidget.Value.Type == nil || (*dstWidget.Value.Type)[0] != openapi3.TypeString {
+		t.Fatalf("dst's original Widget was clobbered: %+v", dstWidget)
+	}
+
+	srcWidget, ok := dst.Components.Schemas["Widget2"]
+	if !ok {
+		t.Fatalf("src's colliding Widget was not renamed to Widget2; have %v", mapKeys(dst.Components.Schemas))
+	}
+	if _, hasName := srcWidget.Value.Properties["name"]; !hasName {
+		t.Errorf("Widget2 should be src's object schema, got %+v", srcWidget.Value)
+	}
+
+	gadgetSchema, ok := dst.Components.Schemas["Gadget"]
+	if !ok {
+		t.Fatalf("Gadget was not merged in; have %v", mapKeys(dst.Components.Schemas))
+	}
+	gotRef := gadgetSchema.Value.Properties["widget"].Ref
+	wantRef := "#/components/schemas/Widget2"
+	if gotRef != wantRef {
+		t.Errorf("Gadget.widget ref: got %q, want %q", gotRef, wantRef)
+	}
+}
@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type oneOfRegistration struct {
+	impls         []reflect.Type
+	discriminator string
+}
+
+// RegisterOneOf tells getSchema how to describe the interface type iface:
+// as a oneOf schema listing the given impls, discriminated by the JSON
+// field named discriminatorTag (each impl's struct field of that name
+// must carry a single-value `enum:"..."` tag giving its discriminator
+// value, per the enum support added to getSchema). discriminatorTag
+// defaults to "type" when empty.
+//
+// iface must be a nil pointer to the interface type, e.g.
+// api.RegisterOneOf((*Shape)(nil), "type", Circle{}, Square{}).
+func (api *API) RegisterOneOf(iface interface{}, discriminatorTag string, impls ...interface{}) {
+	ifaceType := reflect.TypeOf(iface).Elem()
+	if discriminatorTag == "" {
+		discriminatorTag = "type"
+	}
+	implTypes := make([]reflect.Type, len(impls))
+	for i, impl := range impls {
+		implTypes[i] = reflect.TypeOf(impl)
+	}
+	if api.oneOfs == nil {
+		api.oneOfs = make(map[reflect.Type]oneOfRegistration)
+	}
+	api.oneOfs[ifaceType] = oneOfRegistration{impls: implTypes, discriminator: discriminatorTag}
+}
+
+// getOneOfSchema builds the oneOf/discriminator schema for the interface
+// type t, which must have been registered with RegisterOneOf.
+func (api *API) getOneOfSchema(schemas openapi3.Schemas, t reflect.Type) (*openapi3.SchemaRef, error) {
+	reg, ok := api.oneOfs[t]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type: interface %v has no impls registered (see API.RegisterOneOf)", t)
+	}
+
+	schema := &openapi3.Schema{
+		Discriminator: &openapi3.Discriminator{
+			PropertyName: reg.discriminator,
+			Mapping:      make(map[string]string),
+		},
+	}
+	for _, implType := range reg.impls {
+		ref, err := api.getSchema(schemas, implType, getSchemaOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting schema for oneOf impl %v: %w", implType, err)
+		}
+		value, err := discriminatorValue(implType, reg.discriminator)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving discriminator for oneOf impl %v: %w", implType, err)
+		}
+		schema.OneOf = append(schema.OneOf, ref)
+		schema.Discriminator.Mapping[value] = ref.Ref
+	}
+	return openapi3.NewSchemaRef("", schema), nil
+}
+
+// discriminatorValue returns the single enum value tagged on t's field
+// named discriminator, which getOneOfSchema uses as that impl's
+// discriminator mapping key.
+func discriminatorValue(t reflect.Type, discriminator string) (string, error) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		if name != discriminator {
+			continue
+		}
+		enum, ok := f.Tag.Lookup("enum")
+		if !ok {
+			return "", fmt.Errorf("field %q must have an `enum:\"value\"` tag to serve as a oneOf discriminator", f.Name)
+		}
+		return strings.Split(enum, ",")[0], nil
+	}
+	return "", fmt.Errorf("type %v has no field named %q to serve as a discriminator", t, discriminator)
+}
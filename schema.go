@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -24,7 +26,7 @@ func newSpec(name string) *openapi3.T {
 			Schemas:    make(openapi3.Schemas),
 			Extensions: map[string]interface{}{},
 		},
-		Paths:      openapi3.Paths{},
+		Paths:      openapi3.NewPaths(),
 		Extensions: map[string]interface{}{},
 	}
 }
@@ -37,7 +39,28 @@ func (api *API) createOpenAPI() (spec *openapi3.T, err error) {
 		methodToOperation := make(map[string]*openapi3.Operation)
 		for _, method := range allMethods {
 			if models, hasMethod := r.MethodToModels[method]; hasMethod {
-				op := &openapi3.Operation{}
+				op := &openapi3.Operation{
+					Tags:        r.Tags,
+					OperationID: r.OperationID,
+					Summary:     r.Summary,
+					Description: r.Description,
+					Deprecated:  r.Deprecated,
+				}
+
+				for _, param := range r.effectiveParams() {
+					ref, err := api.getSchema(spec.Components.Schemas, param.Type, getSchemaOpts{})
+					if err != nil {
+						return spec, fmt.Errorf("error getting schema for param %q: %w", param.Name, err)
+					}
+					op.Parameters = append(op.Parameters, &openapi3.ParameterRef{
+						Value: &openapi3.Parameter{
+							Name:     param.Name,
+							In:       string(param.In),
+							Required: param.Required,
+							Schema:   ref,
+						},
+					})
+				}
 
 				// Handle request types.
 				if models.Request.Type != nil {
@@ -48,11 +71,7 @@ func (api *API) createOpenAPI() (spec *openapi3.T, err error) {
 					op.RequestBody = &openapi3.RequestBodyRef{
 						Value: &openapi3.RequestBody{
 							Description: "",
-							Content: map[string]*openapi3.MediaType{
-								"application/json": {
-									Schema: ref,
-								},
-							},
+							Content:     api.mediaTypesFor(ref, api.contentTypes()),
 						},
 					}
 				}
@@ -65,14 +84,14 @@ func (api *API) createOpenAPI() (spec *openapi3.T, err error) {
 					}
 					op.AddResponse(status, &openapi3.Response{
 						Description: pointerTo(""),
-						Content: map[string]*openapi3.MediaType{
-							"application/json": {
-								Schema: ref,
-							},
-						},
+						Content:     api.mediaTypesFor(ref, api.responseContentTypes()),
 					})
 				}
 
+				if r.DisableValidation {
+					op.Extensions = map[string]interface{}{extDisableValidation: true}
+				}
+
 				// Register the method.
 				methodToOperation[method] = op
 			}
@@ -103,7 +122,7 @@ func (api *API) createOpenAPI() (spec *openapi3.T, err error) {
 				return spec, fmt.Errorf("unknown HTTP method: %v", method)
 			}
 		}
-		spec.Paths[r.Path] = path
+		spec.Paths.Set(r.Path, path)
 	}
 
 	data, err := spec.MarshalJSON()
@@ -118,16 +137,43 @@ func (api *API) createOpenAPI() (spec *openapi3.T, err error) {
 		return spec, fmt.Errorf("failed validation: %w", err)
 	}
 
+	api.spec = spec
 	return spec, err
 }
 
+// Spec returns the OpenAPI document describing api, generating and caching
+// it on first use. Call createOpenAPI directly instead if the routes have
+// changed since the last call and the cache needs to be refreshed.
+func (api *API) Spec() (*openapi3.T, error) {
+	if api.spec != nil {
+		return api.spec, nil
+	}
+	return api.createOpenAPI()
+}
+
 func pointerTo[T any](v T) *T {
 	return &v
 }
 
+// mediaTypesFor builds the Content map describing ref under every mime
+// type in mimeTypes (either api.contentTypes() for a request body or
+// api.responseContentTypes() for a response, since some codecs, like the
+// form codec, can decode but not encode).
+func (api *API) mediaTypesFor(ref *openapi3.SchemaRef, mimeTypes []string) map[string]*openapi3.MediaType {
+	content := make(map[string]*openapi3.MediaType)
+	for _, mimeType := range mimeTypes {
+		content[mimeType] = &openapi3.MediaType{Schema: ref}
+	}
+	return content
+}
+
 type getSchemaOpts struct {
 	IsPointer  bool
 	IsEmbedded bool
+	// Tag is the struct tag of the field this type was found on, if any.
+	// It's consulted for the "enum", "format", "minimum", "maximum",
+	// "minLength", "maxLength", and "pattern" constraints.
+	Tag reflect.StructTag
 }
 
 func (api *API) getSchema(schemas openapi3.Schemas, t reflect.Type, opts getSchemaOpts) (s *openapi3.SchemaRef, err error) {
@@ -152,9 +198,24 @@ func (api *API) getSchema(schemas openapi3.Schemas, t reflect.Type, opts getSche
 		// Return a reference to it.
 		return openapi3.NewSchemaRef(fmt.Sprintf("#/components/schemas/%s", schemaName), nil), nil
 	}
+	if schema, ok := wellKnownSchema(t, opts); ok {
+		return openapi3.NewSchemaRef("", schema), nil
+	}
+	if t.Kind() == reflect.Interface {
+		return api.getOneOfSchema(schemas, t)
+	}
 
 	switch t.Kind() {
 	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte marshals as a base64 string; json.RawMessage and byte
+			// arrays are given the same schema.
+			return openapi3.NewSchemaRef("", &openapi3.Schema{
+				Type:     &openapi3.Types{openapi3.TypeString},
+				Format:   "byte",
+				Nullable: opts.IsPointer,
+			}), nil
+		}
 		arraySchema := openapi3.NewArraySchema()
 		arraySchema.Nullable = true // Arrays are always nilable in Go.
 		arraySchema.Items, err = api.getSchema(schemas, t.Elem(), getSchemaOpts{})
@@ -162,24 +223,42 @@ func (api *API) getSchema(schemas openapi3.Schemas, t reflect.Type, opts getSche
 			return
 		}
 		return openapi3.NewSchemaRef("", arraySchema), nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type: %v (only string keys are supported)", t.Key())
+		}
+		valueSchema, err := api.getSchema(schemas, t.Elem(), getSchemaOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting schema of map value %v: %w", t.Elem(), err)
+		}
+		mapSchema := openapi3.NewObjectSchema()
+		mapSchema.Nullable = true
+		mapSchema.AdditionalProperties = openapi3.AdditionalProperties{Schema: valueSchema}
+		return openapi3.NewSchemaRef("", mapSchema), nil
 	case reflect.String:
-		return openapi3.NewSchemaRef("", &openapi3.Schema{
-			Type:     openapi3.TypeString,
+		schema := &openapi3.Schema{
+			Type:     &openapi3.Types{openapi3.TypeString},
 			Nullable: opts.IsPointer,
-		}), nil
+		}
+		applyTagConstraints(schema, opts.Tag)
+		return openapi3.NewSchemaRef("", schema), nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		return openapi3.NewSchemaRef("", &openapi3.Schema{
-			Type:     openapi3.TypeInteger,
+		schema := &openapi3.Schema{
+			Type:     &openapi3.Types{openapi3.TypeInteger},
 			Nullable: opts.IsPointer,
-		}), nil
+		}
+		applyTagConstraints(schema, opts.Tag)
+		return openapi3.NewSchemaRef("", schema), nil
 	case reflect.Float64, reflect.Float32:
-		return openapi3.NewSchemaRef("", &openapi3.Schema{
-			Type:     openapi3.TypeNumber,
+		schema := &openapi3.Schema{
+			Type:     &openapi3.Types{openapi3.TypeNumber},
 			Nullable: opts.IsPointer,
-		}), nil
+		}
+		applyTagConstraints(schema, opts.Tag)
+		return openapi3.NewSchemaRef("", schema), nil
 	case reflect.Bool:
 		return openapi3.NewSchemaRef("", &openapi3.Schema{
-			Type:     openapi3.TypeBoolean,
+			Type:     &openapi3.Types{openapi3.TypeBoolean},
 			Nullable: opts.IsPointer,
 		}), nil
 	case reflect.Pointer:
@@ -191,6 +270,15 @@ func (api *API) getSchema(schemas openapi3.Schemas, t reflect.Type, opts getSche
 	case reflect.Struct:
 		schema := openapi3.NewObjectSchema()
 		schema.Properties = make(openapi3.Schemas)
+		value := openapi3.NewSchemaRef("", schema)
+		if !opts.IsEmbedded {
+			// Register the (still-empty) schema before walking fields, so
+			// a self-referential or mutually recursive field resolves to
+			// "#/components/schemas/<name>" instead of recursing forever.
+			// schema.Properties is filled in place below, so the ref seen
+			// by earlier callers ends up complete once we return.
+			schemas[schemaName] = value
+		}
 		for i := 0; i < t.NumField(); i++ {
 			f := t.Field(i)
 			if !f.IsExported() {
@@ -218,13 +306,17 @@ func (api *API) getSchema(schemas openapi3.Schemas, t reflect.Type, opts getSche
 				}
 				continue
 			}
-			schema.Properties[name], err = api.getSchema(schemas, f.Type, getSchemaOpts{})
+			schema.Properties[name], err = api.getSchema(schemas, f.Type, getSchemaOpts{Tag: f.Tag})
+			if err != nil {
+				return nil, fmt.Errorf("error getting schema of field %q: %w", f.Name, err)
+			}
+			if isRequiredTag(f.Tag) {
+				schema.Required = append(schema.Required, name)
+			}
 		}
-		value := openapi3.NewSchemaRef("", schema)
 		if opts.IsEmbedded {
 			return value, nil
 		}
-		schemas[schemaName] = value
 
 		// Return a reference.
 		return openapi3.NewSchemaRef(fmt.Sprintf("#/components/schemas/%s", schemaName), nil), nil
@@ -233,6 +325,94 @@ func (api *API) getSchema(schemas openapi3.Schemas, t reflect.Type, opts getSche
 	return nil, fmt.Errorf("unsupported type: %v/%v", t.PkgPath(), t.Name())
 }
 
+// applyTagConstraints reads the "enum", "format", "minimum", "maximum",
+// "minLength", "maxLength", and "pattern" struct tags from tag and
+// applies any that are present to schema.
+func applyTagConstraints(schema *openapi3.Schema, tag reflect.StructTag) {
+	if enum, ok := tag.Lookup("enum"); ok {
+		for _, v := range strings.Split(enum, ",") {
+			schema.Enum = append(schema.Enum, v)
+		}
+	}
+	if format, ok := tag.Lookup("format"); ok {
+		schema.Format = format
+	}
+	if min, ok := parseFloatTag(tag, "minimum"); ok {
+		schema.Min = &min
+	}
+	if max, ok := parseFloatTag(tag, "maximum"); ok {
+		schema.Max = &max
+	}
+	if minLength, ok := parseUintTag(tag, "minLength"); ok {
+		schema.MinLength = minLength
+	}
+	if maxLength, ok := parseUintTag(tag, "maxLength"); ok {
+		schema.MaxLength = &maxLength
+	}
+	if pattern, ok := tag.Lookup("pattern"); ok {
+		schema.Pattern = pattern
+	}
+}
+
+// isRequiredTag reports whether tag carries a `binding:"required"` entry,
+// matching the convention used by libraries such as go-playground/validator.
+func isRequiredTag(tag reflect.StructTag) bool {
+	binding, ok := tag.Lookup("binding")
+	if !ok {
+		return false
+	}
+	for _, rule := range strings.Split(binding, ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFloatTag(tag reflect.StructTag, key string) (float64, bool) {
+	raw, ok := tag.Lookup(key)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	return v, err == nil
+}
+
+func parseUintTag(tag reflect.StructTag, key string) (uint64, bool) {
+	raw, ok := tag.Lookup(key)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(raw, 10, 64)
+	return v, err == nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// wellKnownSchema returns the schema for t if it's one of a handful of
+// standard or widely-used types whose JSON representation getSchema
+// can't infer from their Go struct/array shape. uuid.UUID and
+// net/netip addresses are matched by package path and name rather than
+// by importing those packages, so recognizing them doesn't force a
+// dependency on github.com/google/uuid for callers who don't use it.
+func wellKnownSchema(t reflect.Type, opts getSchemaOpts) (*openapi3.Schema, bool) {
+	if t == timeType {
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Format: "date-time", Nullable: opts.IsPointer}, true
+	}
+	switch t.PkgPath() + "." + t.Name() {
+	case "github.com/google/uuid.UUID":
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Format: "uuid", Nullable: opts.IsPointer}, true
+	case "net/netip.Addr":
+		// netip.Addr holds both IPv4 and IPv6 addresses, and there's no
+		// way to tell which from the type alone, so no single one of
+		// kin-openapi's ipv4/ipv6 formats (PR #258) describes every
+		// value correctly. Leave Format unset rather than assert one
+		// that's wrong for half of all possible addresses.
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Nullable: opts.IsPointer}, true
+	}
+	return nil, false
+}
+
 var normalizer = strings.NewReplacer("/", "_", ".", "_")
 
 func (api *API) normalizeTypeName(pkgPath, name string) string {
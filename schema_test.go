@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// selfRefNode references itself directly, through a pointer, a slice of
+// pointers, and a map of pointers, all of the parent type.
+type selfRefNode struct {
+	Value    string                  `json:"value"`
+	Parent   *selfRefNode            `json:"parent"`
+	Children []*selfRefNode          `json:"children"`
+	ByName   map[string]*selfRefNode `json:"byName"`
+}
+
+// mutualA and mutualB reference each other, so neither can finish
+// building its schema before the other has a placeholder registered.
+type mutualA struct {
+	Name string   `json:"name"`
+	B    *mutualB `json:"b"`
+}
+
+type mutualB struct {
+	Name string   `json:"name"`
+	A    *mutualA `json:"a"`
+}
+
+func TestGetSchemaDirectSelfReference(t *testing.T) {
+	api := NewAPI("test")
+	schemas := make(openapi3.Schemas)
+
+	ref, err := api.getSchema(schemas, reflect.TypeOf(selfRefNode{}), getSchemaOpts{})
+	if err != nil {
+		t.Fatalf("getSchema: %v", err)
+	}
+
+	name := strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+	schema, ok := schemas[name]
+	if !ok {
+		t.Fatalf("schema %q not registered", name)
+	}
+	selfRef := "#/components/schemas/" + name
+
+	for _, field := range []string{"parent", "children", "byName"} {
+		prop, ok := schema.Value.Properties[field]
+		if !ok {
+			t.Fatalf("missing property %q", field)
+		}
+		switch field {
+		case "parent":
+			if prop.Ref != selfRef {
+				t.Errorf("parent: got ref %q, want %q", prop.Ref, selfRef)
+			}
+		case "children":
+			if got := prop.Value.Items.Ref; got != selfRef {
+				t.Errorf("children items: got ref %q, want %q", got, selfRef)
+			}
+		case "byName":
+			if got := prop.Value.AdditionalProperties.Schema.Ref; got != selfRef {
+				t.Errorf("byName values: got ref %q, want %q", got, selfRef)
+			}
+		}
+	}
+}
+
+func TestGetSchemaMutualRecursion(t *testing.T) {
+	api := NewAPI("test")
+	schemas := make(openapi3.Schemas)
+
+	if _, err := api.getSchema(schemas, reflect.TypeOf(mutualA{}), getSchemaOpts{}); err != nil {
+		t.Fatalf("getSchema: %v", err)
+	}
+
+	for _, t2 := range []reflect.Type{reflect.TypeOf(mutualA{}), reflect.TypeOf(mutualB{})} {
+		name := api.normalizeTypeName(t2.PkgPath(), t2.Name())
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("schema %q not registered; have %v", name, mapKeys(schemas))
+		}
+	}
+}
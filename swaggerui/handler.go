@@ -1,25 +1,198 @@
+// Package swaggerui serves a browsable API console for a generated
+// OpenAPI spec, either as Swagger UI or as Redoc.
 package swaggerui
 
 import (
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"net/http"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	swaggerFiles "github.com/swaggo/files/v2"
 )
 
-func New(spec *openapi3.T) (h http.Handler, err error) {
+// Options configures the console New and NewRedoc produce.
+type Options struct {
+	// BasePath is the path the console is mounted under, e.g.
+	// "/swagger-ui" or "/redoc". Defaults to "/swagger-ui" for New and
+	// "/redoc" for NewRedoc.
+	BasePath string
+	// Title sets the HTML page's <title>. Defaults to "API Documentation".
+	Title string
+	// DarkTheme applies a minimal dark color scheme to the page.
+	DarkTheme bool
+	// ServerURL, if set, is added to the spec as its first server so
+	// "Try it out" requests default to it regardless of what the spec
+	// itself declares.
+	ServerURL string
+	// OAuth2ClientID, if set, pre-fills Swagger UI's OAuth2 authorize
+	// form. It has no effect on NewRedoc.
+	OAuth2ClientID string
+	// RequestInterceptor, if set, is inlined verbatim (not HTML/JS
+	// escaped) as the body of Swagger UI's requestInterceptor(req) hook,
+	// e.g. "req.headers['X-Api-Key'] = 'demo'; return req;". It has no
+	// effect on NewRedoc. Like KnownTypes and codecs, it's Go code under
+	// the caller's control, not untrusted input — every other Options
+	// field, including Title, is treated as untrusted and HTML-escaped.
+	RequestInterceptor string
+}
+
+func (o Options) withDefaults(defaultBasePath string) Options {
+	if o.BasePath == "" {
+		o.BasePath = defaultBasePath
+	}
+	o.BasePath = "/" + strings.Trim(o.BasePath, "/")
+	if o.Title == "" {
+		o.Title = "API Documentation"
+	}
+	return o
+}
+
+func specWithServer(spec *openapi3.T, serverURL string) ([]byte, error) {
+	if serverURL != "" {
+		cloned := *spec
+		cloned.Servers = append(openapi3.Servers{{URL: serverURL}}, spec.Servers...)
+		spec = &cloned
+	}
 	specBytes, err := json.MarshalIndent(spec, "", " ")
 	if err != nil {
-		return h, fmt.Errorf("swaggerui: failed to marshal specification: %w", err)
+		return nil, fmt.Errorf("failed to marshal specification: %w", err)
+	}
+	return specBytes, nil
+}
+
+// New serves an interactive Swagger UI under opts.BasePath (default
+// "/swagger-ui"), using the real swagger-ui static assets embedded by
+// github.com/swaggo/files. The spec itself is served as
+// "<BasePath>/swagger.json".
+func New(spec *openapi3.T, opts Options) (http.Handler, error) {
+	opts = opts.withDefaults("/swagger-ui")
+	specBytes, err := specWithServer(spec, opts.ServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("swaggerui: %w", err)
+	}
+	index, err := renderTemplate(swaggerIndexTemplate, opts)
+	if err != nil {
+		return nil, fmt.Errorf("swaggerui: failed to render index.html: %w", err)
 	}
 
 	m := http.NewServeMux()
+	m.HandleFunc(opts.BasePath+"/swagger.json", serveJSON(specBytes))
+	m.HandleFunc(opts.BasePath+"/index.html", serveHTML(index))
+	m.Handle(opts.BasePath+"/", http.StripPrefix(opts.BasePath, http.FileServer(http.FS(swaggerFiles.FS))))
+	m.HandleFunc(opts.BasePath, redirectToIndex(opts.BasePath))
+	return m, nil
+}
 
-	m.HandleFunc("/swagger-ui/swagger.json", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
-		w.Write(specBytes)
-	})
+// NewRedoc serves a Redoc-rendered view of spec under opts.BasePath
+// (default "/redoc"). Unlike New, this needs no embedded assets: Redoc's
+// standalone bundle is loaded from its public CDN, since the module
+// doesn't vendor one the way swaggo/files vendors Swagger UI's.
+func NewRedoc(spec *openapi3.T, opts Options) (http.Handler, error) {
+	opts = opts.withDefaults("/redoc")
+	specBytes, err := specWithServer(spec, opts.ServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("swaggerui: %w", err)
+	}
+	index, err := renderTemplate(redocIndexTemplate, opts)
+	if err != nil {
+		return nil, fmt.Errorf("swaggerui: failed to render index.html: %w", err)
+	}
 
+	m := http.NewServeMux()
+	m.HandleFunc(opts.BasePath+"/openapi.json", serveJSON(specBytes))
+	m.HandleFunc(opts.BasePath+"/", serveHTML(index))
 	return m, nil
 }
+
+func serveJSON(body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+func serveHTML(body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(body)
+	}
+}
+
+func redirectToIndex(basePath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, basePath+"/index.html", http.StatusFound)
+	}
+}
+
+// templateData wraps Options for execution: every field renders through
+// html/template's contextual auto-escaping except RequestInterceptorJS,
+// which is deliberately typed as template.JS so the trusted, literal JS
+// in Options.RequestInterceptor survives instead of being escaped as a
+// JS string literal.
+type templateData struct {
+	Options
+	RequestInterceptorJS template.JS
+}
+
+func renderTemplate(tmpl *template.Template, opts Options) ([]byte, error) {
+	data := templateData{
+		Options:              opts,
+		RequestInterceptorJS: template.JS(opts.RequestInterceptor),
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+var swaggerIndexTemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html{{if .DarkTheme}} data-theme="dark"{{end}}>
+<head>
+  <meta charset="utf-8" />
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" type="text/css" href="./swagger-ui.css" />
+  <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32" />
+{{if .DarkTheme}}  <style>
+    body { background: #1b1b1b; }
+    .swagger-ui { filter: invert(88%) hue-rotate(180deg); }
+    .swagger-ui .microlight { filter: invert(100%) hue-rotate(180deg); }
+  </style>
+{{end}}</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="./swagger-ui-bundle.js"></script>
+  <script src="./swagger-ui-standalone-preset.js"></script>
+  <script>
+    window.onload = function() {
+      window.ui = SwaggerUIBundle({
+        url: "./swagger.json",
+        dom_id: "#swagger-ui",
+        presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+        layout: "StandaloneLayout",
+{{if .OAuth2ClientID}}        oauth2RedirectUrl: window.location.origin + "{{.BasePath}}/oauth2-redirect.html",
+{{end}}{{if .RequestInterceptor}}        requestInterceptor: function(req) { {{.RequestInterceptorJS}} },
+{{end}}      });
+{{if .OAuth2ClientID}}      window.ui.initOAuth({ clientId: "{{.OAuth2ClientID}}" });
+{{end}}    };
+  </script>
+</body>
+</html>
+`))
+
+var redocIndexTemplate = template.Must(template.New("redoc").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8" />
+  <title>{{.Title}}</title>
+  <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+  <redoc spec-url="./openapi.json"{{if .DarkTheme}} theme='{"colors":{"background":{"main":"#1b1b1b"}},"typography":{"fontSize":"14px"}}'{{end}}></redoc>
+  <script src="https://cdn.jsdelivr.net/npm/redoc@2/bundles/redoc.standalone.js"></script>
+</body>
+</html>
+`))
@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// ValidatorOptions configures ValidatorMiddleware.
+type ValidatorOptions struct {
+	// FailFast stops at the first violation instead of collecting every
+	// violation found in the request/response into a single error. It
+	// is false by default, matching the aggregating behavior added in
+	// kin-openapi's openapi3filter (see PR #259).
+	FailFast bool
+	// ValidateResponses also runs the generated schemas against the
+	// handler's response body. It is disabled by default since it
+	// requires buffering the response.
+	ValidateResponses bool
+	// ErrorRenderer writes err to w in response to a validation failure.
+	// It defaults to renderValidationError, which writes a 400 with the
+	// error's message as plain text.
+	ErrorRenderer func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func (o ValidatorOptions) withDefaults() ValidatorOptions {
+	if o.ErrorRenderer == nil {
+		o.ErrorRenderer = renderValidationError
+	}
+	return o
+}
+
+func renderValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+// ValidatorMiddleware returns chi-compatible middleware that validates
+// incoming requests, and optionally outgoing responses, against api's
+// generated OpenAPI spec. Set route.DisableValidation on a Route to
+// exempt it from validation.
+func (api *API) ValidatorMiddleware(opts ValidatorOptions) (func(http.Handler) http.Handler, error) {
+	opts = opts.withDefaults()
+
+	spec, err := api.Spec()
+	if err != nil {
+		return nil, fmt.Errorf("rest: failed to build spec for validator middleware: %w", err)
+	}
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("rest: failed to build router for validator middleware: %w", err)
+	}
+
+	validateOpts := &openapi3filter.Options{MultiError: !opts.FailFast}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				// The spec has nothing to say about this request; let the
+				// underlying router produce the 404/405.
+				next.ServeHTTP(w, r)
+				return
+			}
+			if isValidationDisabled(route) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reqInput := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    validateOpts,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), reqInput); err != nil {
+				opts.ErrorRenderer(w, r, err)
+				return
+			}
+
+			if !opts.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := newResponseRecorder(w)
+			next.ServeHTTP(rec, r)
+			respInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: reqInput,
+				Status:                 rec.status,
+				Header:                 rec.Header(),
+				Body:                   rec.bodyReader(),
+			}
+			if err := openapi3filter.ValidateResponse(r.Context(), respInput); err != nil {
+				opts.ErrorRenderer(w, r, err)
+				return
+			}
+			rec.flush()
+		})
+	}, nil
+}
+
+// isValidationDisabled reports whether route matches a Route that was
+// registered with WithValidationDisabled.
+func isValidationDisabled(route *routers.Route) bool {
+	disabled, _ := route.Operation.Extensions[extDisableValidation].(bool)
+	return disabled
+}
+
+const extDisableValidation = "x-rest-disable-validation"
+
+// responseRecorder buffers a handler's response so it can be validated
+// before being written to the real ResponseWriter.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+func (rec *responseRecorder) bodyReader() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(rec.body.Bytes()))
+}
+
+// flush writes the buffered status and body to the underlying
+// ResponseWriter once validation has passed.
+func (rec *responseRecorder) flush() {
+	rec.ResponseWriter.WriteHeader(rec.status)
+	rec.ResponseWriter.Write(rec.body.Bytes())
+}